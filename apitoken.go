@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oxechicao/turnstyle/token"
+)
+
+// ErrInvalidToken is returned when a bearer token fails verification or has
+// been revoked.
+var ErrInvalidToken = errors.New("turnstyle: invalid or revoked token")
+
+// IssueToken mints a signed API token for the user identified by id. A
+// positive ttl produces an expiring token; ttl <= 0 mints a token with no
+// expiration.
+func (us *UserService) IssueToken(tokens TokenStore, id int, ttl time.Duration) (string, error) {
+	user, err := us.GetUser(id)
+	if err != nil {
+		return "", err
+	}
+
+	jti, err := randomHex(16)
+	if err != nil {
+		return "", fmt.Errorf("generate token id: %w", err)
+	}
+
+	var signed string
+	if ttl > 0 {
+		signed, err = token.SignExpiring(user.ID, user.TokenSecret, jti, ttl)
+	} else {
+		signed, err = token.SignUnlimited(user.ID, user.TokenSecret, jti)
+	}
+	if err != nil {
+		return "", fmt.Errorf("sign token: %w", err)
+	}
+
+	if err := tokens.Save(&IssuedToken{JTI: jti, UserID: user.ID, IssuedAt: time.Now()}); err != nil {
+		return "", fmt.Errorf("save issued token: %w", err)
+	}
+
+	return signed, nil
+}
+
+// ResolveToken verifies a bearer token's signature and that it has not been
+// revoked, and returns the user it was issued to.
+func (us *UserService) ResolveToken(tokens TokenStore, bearer string) (*User, error) {
+	peek, err := token.ParseUnverified(bearer)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	user, err := us.GetUser(peek.UserID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	claims, err := token.Parse(bearer, user.TokenSecret)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	issued, err := tokens.GetToken(claims.ID)
+	if err != nil || !issued.RevokedAt.IsZero() {
+		return nil, ErrInvalidToken
+	}
+
+	if !user.IsActive {
+		return nil, ErrInvalidToken
+	}
+
+	return user, nil
+}
+
+// RevokeTokens invalidates every outstanding API token for the user
+// identified by id: it rotates their signing secret (so any token a
+// verifier hasn't seen yet fails signature checks too) and marks issued
+// tokens revoked in the store.
+func (us *UserService) RevokeTokens(tokens TokenStore, id int) error {
+	user, err := us.GetUser(id)
+	if err != nil {
+		return err
+	}
+
+	secret, err := randomHex(32)
+	if err != nil {
+		return fmt.Errorf("rotate token secret: %w", err)
+	}
+	user.TokenSecret = secret
+	if err := us.store.Update(user); err != nil {
+		return err
+	}
+
+	return tokens.RevokeAll(id)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+const (
+	sessionCookieName = "Turnstyle-Token"
+	xsrfHeaderName    = "X-XSRFToken"
+)
+
+type contextKey int
+
+const (
+	userContextKey contextKey = iota
+	sessionUserIDContextKey
+)
+
+// UserFromContext returns the user injected by requireBearer, if any.
+func UserFromContext(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(userContextKey).(*User)
+	return user, ok
+}
+
+// requestUserID returns the ID of the user associated with r, as
+// established by requireBearer or requireSession. It returns 0 if the
+// request carried no authenticated user, e.g. an unauthenticated GET.
+func requestUserID(r *http.Request) int {
+	if user, ok := UserFromContext(r.Context()); ok {
+		return user.ID
+	}
+	if id, ok := r.Context().Value(sessionUserIDContextKey).(int); ok {
+		return id
+	}
+	return 0
+}
+
+// requireBearer wraps next so the request must carry a valid
+// "Authorization: Bearer <token>" header minted by POST /users/{id}/token.
+// The resolved user is injected into the request context, retrievable with
+// UserFromContext.
+func requireBearer(service *UserService, tokens TokenStore, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			writeJSONError(w, http.StatusUnauthorized, "missing bearer token")
+			return
+		}
+
+		user, err := service.ResolveToken(tokens, bearer)
+		if err != nil {
+			writeJSONError(w, http.StatusUnauthorized, "invalid or expired token")
+			return
+		}
+
+		next(w, r.WithContext(context.WithValue(r.Context(), userContextKey, user)))
+	}
+}
+
+// requireSession wraps next so that state-changing requests (everything but
+// GET) must carry a valid session cookie and its matching XSRF header.
+// Login rotates both on every call, so a stolen cookie alone is not enough.
+// The session's user is re-checked against service on every call, so
+// disabling a user revokes their outstanding sessions immediately.
+func requireSession(service *UserService, sessions *SessionStore, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			next(w, r)
+			return
+		}
+
+		cookie, err := r.Cookie(sessionCookieName)
+		if err != nil {
+			writeJSONError(w, http.StatusForbidden, "missing session cookie")
+			return
+		}
+
+		session, err := sessions.Validate(cookie.Value, r.Header.Get(xsrfHeaderName))
+		if err != nil {
+			writeJSONError(w, http.StatusForbidden, "invalid session or XSRF token")
+			return
+		}
+
+		user, err := service.GetUser(session.UserID)
+		if err != nil || !user.IsActive {
+			writeJSONError(w, http.StatusForbidden, "invalid session or XSRF token")
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), sessionUserIDContextKey, session.UserID)
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// loginRequest is the payload accepted by POST /login.
+type loginRequest struct {
+	Email    string `json:"email" form:"email" validate:"required"`
+	Password string `json:"password" form:"password" validate:"required"`
+}
+
+// handleLogin authenticates credentials against the user store and, on
+// success, issues a session cookie paired with an XSRF token header.
+func handleLogin(service *UserService, sessions *SessionStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		var req loginRequest
+		if err := Bind(r, &req); err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		user, err := service.Authenticate(req.Email, req.Password)
+		if err != nil {
+			writeJSONError(w, http.StatusUnauthorized, "invalid email or password")
+			return
+		}
+
+		session, err := sessions.Create(user.ID)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     sessionCookieName,
+			Value:    session.Token,
+			Path:     "/",
+			HttpOnly: true,
+			Expires:  session.ExpiresAt,
+		})
+		w.Header().Set(xsrfHeaderName, session.XSRFToken)
+
+		writeJSON(w, http.StatusOK, user)
+	}
+}
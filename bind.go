@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+)
+
+// CreateUserRequest is the payload accepted by POST /users, in JSON, XML, or
+// form-urlencoded form.
+type CreateUserRequest struct {
+	Name     string `json:"name" xml:"name" form:"name" validate:"required"`
+	Email    string `json:"email" xml:"email" form:"email" validate:"required"`
+	Password string `json:"password" xml:"password" form:"password" validate:"required"`
+}
+
+// Bind decodes the request body into v based on the request's Content-Type
+// and validates the result. It supports application/json,
+// application/xml, and application/x-www-form-urlencoded bodies.
+func Bind(r *http.Request, v interface{}) error {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		mediaType = "application/json"
+	}
+
+	switch mediaType {
+	case "application/json", "":
+		if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+			return fmt.Errorf("decode json body: %w", err)
+		}
+	case "application/xml", "text/xml":
+		if err := xml.NewDecoder(r.Body).Decode(v); err != nil {
+			return fmt.Errorf("decode xml body: %w", err)
+		}
+	case "application/x-www-form-urlencoded":
+		if err := r.ParseForm(); err != nil {
+			return fmt.Errorf("parse form body: %w", err)
+		}
+		if err := bindForm(r.PostForm, v); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported content type %q", mediaType)
+	}
+
+	return validate(v)
+}
+
+// bindForm copies values into the fields of v tagged with a matching `form`
+// struct tag. Only string fields are supported, which is all the request
+// types in this package need.
+func bindForm(values url.Values, v interface{}) error {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind form: destination must be a pointer to struct")
+	}
+	val = val.Elem()
+	typ := val.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		tag := typ.Field(i).Tag.Get("form")
+		if tag == "" {
+			continue
+		}
+		if value := values.Get(tag); value != "" {
+			val.Field(i).SetString(value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// encodeCursor packs a (sort_key, id) tuple into an opaque pagination
+// cursor. Keeping the tuple opaque lets List's wire format stay the same
+// whether it's backed by MemoryStore or SQLStore.
+func encodeCursor(sortKey string, id int) string {
+	raw := fmt.Sprintf("%s\x00%d", sortKey, id)
+	return base64.URLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor reverses encodeCursor.
+func decodeCursor(cursor string) (sortKey string, id int, err error) {
+	raw, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", 0, fmt.Errorf("decode cursor: %w", err)
+	}
+
+	parts := strings.SplitN(string(raw), "\x00", 2)
+	if len(parts) != 2 {
+		return "", 0, fmt.Errorf("malformed cursor")
+	}
+
+	id, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return "", 0, fmt.Errorf("malformed cursor id: %w", err)
+	}
+	return parts[0], id, nil
+}
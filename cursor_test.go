@@ -0,0 +1,29 @@
+package main
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := encodeCursor("0000000042", 42)
+
+	sortKey, id, err := decodeCursor(cursor)
+	if err != nil {
+		t.Fatalf("decodeCursor: %v", err)
+	}
+	if sortKey != "0000000042" || id != 42 {
+		t.Fatalf("decodeCursor = (%q, %d), want (%q, %d)", sortKey, id, "0000000042", 42)
+	}
+}
+
+func TestDecodeCursorMalformed(t *testing.T) {
+	if _, _, err := decodeCursor("not-valid-base64!!"); err == nil {
+		t.Fatal("decodeCursor with invalid base64: got nil error, want error")
+	}
+
+	noSeparator := base64.URLEncoding.EncodeToString([]byte("no-separator"))
+	if _, _, err := decodeCursor(noSeparator); err == nil {
+		t.Fatal("decodeCursor with no sort_key/id separator: got nil error, want error")
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// handleUsers handles HTTP requests for user operations.
+func handleUsers(service *UserService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			// Bulk lookup by ?ids=1,2,3
+			if idsStr := r.URL.Query().Get("ids"); idsStr != "" {
+				ids, err := parseIDList(idsStr)
+				if err != nil {
+					writeJSONError(w, http.StatusBadRequest, "invalid user ID")
+					return
+				}
+
+				users := service.GetMultipleByID(ids...)
+
+				if wantsJSON(r) {
+					writeJSON(w, http.StatusOK, users)
+					return
+				}
+				for _, id := range ids {
+					if user, ok := users[id]; ok {
+						fmt.Fprintf(w, "User: %+v\n", user)
+					}
+				}
+				return
+			}
+
+			// Get user by ID from query parameter
+			idStr := r.URL.Query().Get("id")
+			if idStr != "" {
+				id, err := strconv.Atoi(idStr)
+				if err != nil {
+					writeJSONError(w, http.StatusBadRequest, "invalid user ID")
+					return
+				}
+
+				user, err := service.GetUser(id)
+				if err != nil {
+					writeServiceError(w, err)
+					return
+				}
+
+				if wantsJSON(r) {
+					writeJSON(w, http.StatusOK, user)
+					return
+				}
+				fmt.Fprintf(w, "User: %+v\n", user)
+				return
+			}
+
+			// List users, optionally filtered by ?filter=all|active|disabled
+			// and paginated by ?limit=N&after=<cursor>&sort=created|name
+			filter := ListFilter(r.URL.Query().Get("filter"))
+			opts := ListOptions{
+				After: r.URL.Query().Get("after"),
+				Sort:  r.URL.Query().Get("sort"),
+			}
+			if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+				limit, err := strconv.Atoi(limitStr)
+				if err != nil {
+					writeJSONError(w, http.StatusBadRequest, "invalid limit")
+					return
+				}
+				opts.Limit = limit
+			}
+
+			page, err := service.ListPage(filter, opts)
+			if err != nil {
+				writeJSONError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			if wantsJSON(r) {
+				writeJSON(w, http.StatusOK, userPageEnvelope{Users: page.Users, Next: page.Next})
+				return
+			}
+			fmt.Fprintf(w, "Users: %d\n", len(page.Users))
+			for _, user := range page.Users {
+				fmt.Fprintf(w, "- %s (%s) active=%t\n", user.Name, user.Email, user.IsActive)
+			}
+			if page.Next != "" {
+				fmt.Fprintf(w, "next: %s\n", page.Next)
+			}
+
+		case "POST":
+			var req CreateUserRequest
+			if err := Bind(r, &req); err != nil {
+				writeJSONError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			user, err := service.CreateUser(req.Name, req.Email, req.Password)
+			if err != nil {
+				writeJSONError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			if wantsJSON(r) {
+				writeJSON(w, http.StatusCreated, user)
+				return
+			}
+			fmt.Fprintf(w, "Created user: %+v\n", user)
+
+		case "PATCH":
+			idStr := r.URL.Query().Get("id")
+			id, err := strconv.Atoi(idStr)
+			if err != nil {
+				writeJSONError(w, http.StatusBadRequest, "invalid user ID")
+				return
+			}
+			if requestUserID(r) != id {
+				writeJSONError(w, http.StatusForbidden, "cannot disable or enable another user")
+				return
+			}
+
+			var body struct {
+				Disabled bool `json:"disabled"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				writeJSONError(w, http.StatusBadRequest, "invalid request body")
+				return
+			}
+
+			if err := service.Disable(id, body.Disabled); err != nil {
+				writeServiceError(w, err)
+				return
+			}
+
+			user, err := service.GetUser(id)
+			if err != nil {
+				writeServiceError(w, err)
+				return
+			}
+
+			if wantsJSON(r) {
+				writeJSON(w, http.StatusOK, user)
+				return
+			}
+			fmt.Fprintf(w, "User %d disabled=%t\n", id, body.Disabled)
+
+		default:
+			writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	}
+}
+
+// parseIDList parses a comma-separated list of user IDs, e.g. "1,2,3".
+func parseIDList(raw string) ([]int, error) {
+	parts := strings.Split(raw, ",")
+	ids := make([]int, len(parts))
+	for i, part := range parts {
+		id, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid user ID %q: %w", part, err)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
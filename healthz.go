@@ -0,0 +1,24 @@
+package main
+
+import "net/http"
+
+// handleHealthz reports liveness: the process is up and serving requests.
+func handleHealthz() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	}
+}
+
+// handleReadyz reports readiness: liveness plus store connectivity.
+func handleReadyz(store Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := store.List(); err != nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{
+				"status": "unavailable",
+				"error":  err.Error(),
+			})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	}
+}
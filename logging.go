@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"time"
+)
+
+// requestLog is a single structured request-lifecycle log line, emitted as
+// one JSON object per completed request.
+type requestLog struct {
+	EventTime  string `json:"eventTime"`
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+	Status     int    `json:"status"`
+	DurationMS int64  `json:"duration_ms"`
+	RemoteAddr string `json:"remote_addr"`
+	UserID     int    `json:"user_id,omitempty"`
+}
+
+// statusRecorder captures the status code written by the wrapped handler so
+// it can be included in the log line once the request completes.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// withLogging wraps next to emit one JSON log line per request. It should
+// wrap the outermost handler, before any auth middleware, so that requests
+// auth rejects (missing/invalid bearer token, bad session or XSRF) are
+// logged too; UserID is simply omitted for those since no user was ever
+// attached to the request context.
+func withLogging(next http.HandlerFunc) http.HandlerFunc {
+	encoder := json.NewEncoder(os.Stdout)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next(rec, r)
+
+		encoder.Encode(requestLog{
+			EventTime:  start.UTC().Format(time.RFC3339),
+			Method:     r.Method,
+			Path:       r.URL.Path,
+			Status:     rec.status,
+			DurationMS: time.Since(start).Milliseconds(),
+			RemoteAddr: r.RemoteAddr,
+			UserID:     requestUserID(r),
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// newStore builds the Store named by spec: "memory" (the default) or
+// "sqlite://path" for a SQLite-backed store at path.
+func newStore(spec string) (Store, error) {
+	if spec == "" || spec == "memory" {
+		return NewMemoryStore(), nil
+	}
+	if path, ok := strings.CutPrefix(spec, "sqlite://"); ok {
+		return NewSQLStore(path)
+	}
+	return nil, fmt.Errorf("unknown store %q: want \"memory\" or \"sqlite://path\"", spec)
+}
+
+func main() {
+	storeFlag := flag.String("store", "memory", `storage backend: "memory" or "sqlite://path"`)
+	sessionTTL := flag.Duration("session-ttl", DefaultSessionTTL, "session lifetime before re-login is required")
+	drainTimeout := flag.Duration("drain-timeout", 15*time.Second, "how long to wait for in-flight requests during shutdown")
+	flag.Parse()
+
+	store, err := newStore(*storeFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tokenStore, ok := store.(TokenStore)
+	if !ok {
+		log.Fatalf("store %T does not implement TokenStore", store)
+	}
+
+	userService := NewUserService(store)
+	sessions := NewSessionStore(*sessionTTL)
+
+	// Create some sample users
+	users := []struct {
+		name     string
+		email    string
+		password string
+	}{
+		{"Alice Johnson", "alice@example.com", "alice-password"},
+		{"Bob Smith", "bob@example.com", "bob-password"},
+		{"Carol Davis", "carol@example.com", "carol-password"},
+	}
+
+	for _, userData := range users {
+		user, err := userService.CreateUser(userData.name, userData.email, userData.password)
+		if err != nil {
+			log.Printf("Error creating user: %v", err)
+			continue
+		}
+		fmt.Printf("Created user: %s (ID: %d)\n", user.Name, user.ID)
+	}
+
+	// Setup HTTP server
+	usersHandler := handleUsers(userService)
+	http.HandleFunc("/login", withLogging(handleLogin(userService, sessions)))
+	http.HandleFunc("/users", withLogging(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			requireBearer(userService, tokenStore, usersHandler)(w, r)
+			return
+		}
+		requireSession(userService, sessions, usersHandler)(w, r)
+	}))
+	http.HandleFunc("/users/", withLogging(requireSession(userService, sessions, handleUserTokens(userService, tokenStore))))
+	http.HandleFunc("/healthz", handleHealthz())
+	http.HandleFunc("/readyz", handleReadyz(store))
+
+	server := &http.Server{
+		Addr:         ":8080",
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		fmt.Println("Starting server on :8080")
+		fmt.Println("Try: curl http://localhost:8080/users")
+		fmt.Println("Or:  curl -X POST -d 'email=alice@example.com&password=alice-password' http://localhost:8080/login")
+
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal("Server failed to start:", err)
+		}
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	<-stop
+
+	fmt.Println("Shutting down, waiting for in-flight requests to drain...")
+	ctx, cancel := context.WithTimeout(context.Background(), *drainTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("graceful shutdown error: %v", err)
+	}
+}
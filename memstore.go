@@ -0,0 +1,99 @@
+package main
+
+import "sync"
+
+// MemoryStore is an in-memory Store implementation. It keeps the behavior
+// the service originally had: data lives only for the lifetime of the
+// process and is not shared between processes.
+type MemoryStore struct {
+	mu     sync.Mutex
+	users  map[int]*User
+	nextID int
+	tokens map[string]*IssuedToken
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		users:  make(map[int]*User),
+		nextID: 1,
+		tokens: make(map[string]*IssuedToken),
+	}
+}
+
+func (s *MemoryStore) Create(user *User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.users {
+		if existing.Email == user.Email {
+			return ErrDuplicateEmail
+		}
+	}
+
+	user.ID = s.nextID
+	s.nextID++
+
+	cp := *user
+	s.users[user.ID] = &cp
+	return nil
+}
+
+func (s *MemoryStore) Get(id int) (*User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *user
+	return &cp, nil
+}
+
+func (s *MemoryStore) List() ([]*User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	users := make([]*User, 0, len(s.users))
+	for _, user := range s.users {
+		cp := *user
+		users = append(users, &cp)
+	}
+	return users, nil
+}
+
+func (s *MemoryStore) Update(user *User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[user.ID]; !ok {
+		return ErrNotFound
+	}
+	cp := *user
+	s.users[user.ID] = &cp
+	return nil
+}
+
+func (s *MemoryStore) Disable(id int, disabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return ErrNotFound
+	}
+	user.IsActive = !disabled
+	return nil
+}
+
+func (s *MemoryStore) Delete(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.users, id)
+	return nil
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// randomHex returns n random bytes, hex-encoded.
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// errorEnvelope is the JSON shape returned for all API errors.
+type errorEnvelope struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+// userPageEnvelope is the JSON shape returned for a paginated user listing.
+type userPageEnvelope struct {
+	Users []*User `json:"users"`
+	Next  string  `json:"next,omitempty"`
+}
+
+// writeJSON marshals v as JSON, sets the response Content-Type, and writes
+// status.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeJSONError writes an errorEnvelope with the given status and message.
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorEnvelope{Status: status, Message: message})
+}
+
+// writeServiceError maps a UserService error to the HTTP status it implies.
+func writeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidID):
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, ErrNotFound):
+		writeJSONError(w, http.StatusNotFound, err.Error())
+	default:
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// wantsJSON reports whether the request prefers a JSON response body.
+func wantsJSON(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
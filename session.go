@@ -0,0 +1,110 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultSessionTTL is used when no explicit session TTL is configured.
+const DefaultSessionTTL = 24 * time.Hour
+
+// ErrSessionInvalid is returned when a session token or its paired XSRF
+// token fails validation.
+var ErrSessionInvalid = errors.New("turnstyle: invalid session")
+
+// Session represents a logged-in user's session, along with the XSRF token
+// paired with it.
+type Session struct {
+	Token     string
+	XSRFToken string
+	UserID    int
+	ExpiresAt time.Time
+}
+
+// SessionStore issues and validates sessions for authenticated users.
+type SessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]*Session
+	ttl      time.Duration
+}
+
+// NewSessionStore creates a SessionStore whose sessions expire after ttl. A
+// non-positive ttl falls back to DefaultSessionTTL.
+func NewSessionStore(ttl time.Duration) *SessionStore {
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+	return &SessionStore{
+		sessions: make(map[string]*Session),
+		ttl:      ttl,
+	}
+}
+
+// Create issues a fresh session, and its paired XSRF token, for userID. Any
+// session previously issued to userID is invalidated.
+func (s *SessionStore) Create(userID int) (*Session, error) {
+	token, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	xsrf, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &Session{
+		Token:     token,
+		XSRFToken: xsrf,
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(s.ttl),
+	}
+
+	s.mu.Lock()
+	for existing, old := range s.sessions {
+		if old.UserID == userID {
+			delete(s.sessions, existing)
+		}
+	}
+	s.sessions[token] = session
+	s.mu.Unlock()
+
+	return session, nil
+}
+
+// Validate checks that token names a live, unexpired session whose XSRF
+// token matches xsrf.
+func (s *SessionStore) Validate(token, xsrf string) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[token]
+	if !ok {
+		return nil, ErrSessionInvalid
+	}
+	if time.Now().After(session.ExpiresAt) {
+		delete(s.sessions, token)
+		return nil, ErrSessionInvalid
+	}
+	if xsrf == "" || xsrf != session.XSRFToken {
+		return nil, ErrSessionInvalid
+	}
+	return session, nil
+}
+
+// Delete invalidates the session named by token.
+func (s *SessionStore) Delete(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, token)
+}
+
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionStoreValidate(t *testing.T) {
+	sessions := NewSessionStore(time.Hour)
+
+	session, err := sessions.Create(42)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if _, err := sessions.Validate(session.Token, session.XSRFToken); err != nil {
+		t.Fatalf("Validate with correct XSRF token: %v", err)
+	}
+
+	if _, err := sessions.Validate(session.Token, "wrong-xsrf"); err != ErrSessionInvalid {
+		t.Fatalf("Validate with mismatched XSRF token: got %v, want ErrSessionInvalid", err)
+	}
+
+	if _, err := sessions.Validate("unknown-token", session.XSRFToken); err != ErrSessionInvalid {
+		t.Fatalf("Validate with unknown token: got %v, want ErrSessionInvalid", err)
+	}
+}
+
+func TestSessionStoreValidateExpired(t *testing.T) {
+	sessions := NewSessionStore(time.Hour)
+
+	session, err := sessions.Create(42)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	session.ExpiresAt = time.Now().Add(-time.Minute)
+
+	if _, err := sessions.Validate(session.Token, session.XSRFToken); err != ErrSessionInvalid {
+		t.Fatalf("Validate expired session: got %v, want ErrSessionInvalid", err)
+	}
+
+	// The expired session should also be gone from the store, not just
+	// rejected once.
+	if _, err := sessions.Validate(session.Token, session.XSRFToken); err != ErrSessionInvalid {
+		t.Fatalf("Validate after expiry cleanup: got %v, want ErrSessionInvalid", err)
+	}
+	if _, ok := sessions.sessions[session.Token]; ok {
+		t.Fatal("expired session still present in the store after Validate")
+	}
+}
+
+func TestSessionStoreDelete(t *testing.T) {
+	sessions := NewSessionStore(time.Hour)
+
+	session, err := sessions.Create(42)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	sessions.Delete(session.Token)
+
+	if _, err := sessions.Validate(session.Token, session.XSRFToken); err != ErrSessionInvalid {
+		t.Fatalf("Validate deleted session: got %v, want ErrSessionInvalid", err)
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// schemaMigration is the contents of sql/init.sql, embedded so the binary
+// can apply its schema without the source tree present at runtime.
+//
+//go:embed sql/init.sql
+var schemaMigration string
+
+// SQLStore is a Store implementation backed by SQLite via database/sql.
+type SQLStore struct {
+	db *sql.DB
+}
+
+// NewSQLStore opens (creating if necessary) the SQLite database at path and
+// applies the embedded schema migration.
+func NewSQLStore(path string) (*SQLStore, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite store: %w", err)
+	}
+
+	if _, err := db.Exec(schemaMigration); err != nil {
+		return nil, fmt.Errorf("apply schema migration: %w", err)
+	}
+
+	return &SQLStore{db: db}, nil
+}
+
+func (s *SQLStore) Create(user *User) error {
+	res, err := s.db.Exec(`INSERT INTO users (name, email, is_active, password_hash, token_secret) VALUES (?, ?, ?, ?, ?)`,
+		user.Name, user.Email, user.IsActive, user.PasswordHash, user.TokenSecret)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return ErrDuplicateEmail
+		}
+		return fmt.Errorf("create user: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("create user: %w", err)
+	}
+	user.ID = int(id)
+	return nil
+}
+
+func (s *SQLStore) Get(id int) (*User, error) {
+	row := s.db.QueryRow(`SELECT id, name, email, is_active, password_hash, token_secret FROM users WHERE id = ?`, id)
+
+	user := &User{}
+	if err := row.Scan(&user.ID, &user.Name, &user.Email, &user.IsActive, &user.PasswordHash, &user.TokenSecret); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	return user, nil
+}
+
+func (s *SQLStore) List() ([]*User, error) {
+	rows, err := s.db.Query(`SELECT id, name, email, is_active, password_hash, token_secret FROM users ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*User
+	for rows.Next() {
+		user := &User{}
+		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.IsActive, &user.PasswordHash, &user.TokenSecret); err != nil {
+			return nil, fmt.Errorf("list users: %w", err)
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (s *SQLStore) Update(user *User) error {
+	res, err := s.db.Exec(`UPDATE users SET name = ?, email = ?, is_active = ?, password_hash = ?, token_secret = ? WHERE id = ?`,
+		user.Name, user.Email, user.IsActive, user.PasswordHash, user.TokenSecret, user.ID)
+	if err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+	return rowsAffectedOrNotFound(res)
+}
+
+func (s *SQLStore) Disable(id int, disabled bool) error {
+	res, err := s.db.Exec(`UPDATE users SET is_active = ? WHERE id = ?`, !disabled, id)
+	if err != nil {
+		return fmt.Errorf("disable user: %w", err)
+	}
+	return rowsAffectedOrNotFound(res)
+}
+
+func (s *SQLStore) Delete(id int) error {
+	res, err := s.db.Exec(`DELETE FROM users WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete user: %w", err)
+	}
+	return rowsAffectedOrNotFound(res)
+}
+
+func (s *SQLStore) Save(t *IssuedToken) error {
+	_, err := s.db.Exec(`INSERT INTO tokens (jti, user_id, issued_at) VALUES (?, ?, ?)`,
+		t.JTI, t.UserID, t.IssuedAt)
+	if err != nil {
+		return fmt.Errorf("save issued token: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLStore) GetToken(jti string) (*IssuedToken, error) {
+	row := s.db.QueryRow(`SELECT jti, user_id, issued_at, revoked_at FROM tokens WHERE jti = ?`, jti)
+
+	t := &IssuedToken{}
+	var revokedAt sql.NullTime
+	if err := row.Scan(&t.JTI, &t.UserID, &t.IssuedAt, &revokedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get issued token: %w", err)
+	}
+	if revokedAt.Valid {
+		t.RevokedAt = revokedAt.Time
+	}
+	return t, nil
+}
+
+func (s *SQLStore) RevokeAll(userID int) error {
+	_, err := s.db.Exec(`UPDATE tokens SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL`,
+		time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("revoke issued tokens: %w", err)
+	}
+	return nil
+}
+
+func rowsAffectedOrNotFound(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package main
+
+import "errors"
+
+// ErrNotFound is returned by a Store when the requested user does not exist.
+var ErrNotFound = errors.New("turnstyle: user not found")
+
+// ErrInvalidID is returned when a caller supplies an ID that cannot refer to
+// any user, such as a zero or negative value.
+var ErrInvalidID = errors.New("turnstyle: invalid user id")
+
+// ErrDuplicateEmail is returned by a Store's Create when another user
+// already has the given email.
+var ErrDuplicateEmail = errors.New("turnstyle: email already in use")
+
+// Store is the persistence interface UserService depends on. Implementations
+// are free to keep users in memory or in a backing database, as long as IDs
+// are assigned by the Store itself so they remain stable across restarts.
+type Store interface {
+	Create(user *User) error
+	Get(id int) (*User, error)
+	List() ([]*User, error)
+	Update(user *User) error
+	Disable(id int, disabled bool) error
+	Delete(id int) error
+}
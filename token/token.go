@@ -0,0 +1,73 @@
+// Package token mints and verifies the JWTs turnstyle issues as API tokens.
+// Each token is signed with the secret of the user it was issued for, so
+// rotating that secret invalidates every token issued under it.
+package token
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalid is returned when a token fails verification.
+var ErrInvalid = errors.New("token: invalid or expired token")
+
+// Claims identifies the user a token was issued for, plus the registered
+// claims (notably ID, the jti used for revocation bookkeeping).
+type Claims struct {
+	UserID int `json:"uid"`
+	jwt.RegisteredClaims
+}
+
+// SignExpiring mints a JWT for userID, keyed off secret, that expires after
+// ttl. jti is the caller-assigned token ID used to look up and revoke this
+// token later.
+func SignExpiring(userID int, secret, jti string, ttl time.Duration) (string, error) {
+	return sign(userID, secret, jti, time.Now().Add(ttl))
+}
+
+// SignUnlimited mints a JWT for userID, keyed off secret, with no
+// expiration.
+func SignUnlimited(userID int, secret, jti string) (string, error) {
+	return sign(userID, secret, jti, time.Time{})
+}
+
+func sign(userID int, secret, jti string, expiresAt time.Time) (string, error) {
+	claims := Claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:       jti,
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+		},
+	}
+	if !expiresAt.IsZero() {
+		claims.ExpiresAt = jwt.NewNumericDate(expiresAt)
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+}
+
+// ParseUnverified extracts the claims from tokenString without verifying its
+// signature. Callers must still call Parse with the correct secret before
+// trusting the result; this only exists to recover which user (and thus
+// which secret) a bearer token claims to be for.
+func ParseUnverified(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(tokenString, claims); err != nil {
+		return nil, ErrInvalid
+	}
+	return claims, nil
+}
+
+// Parse verifies tokenString against secret and returns its claims.
+func Parse(tokenString, secret string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	return claims, nil
+}
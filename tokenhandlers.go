@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// handleUserTokens handles POST /users/{id}/token (issue a new API token)
+// and DELETE /users/{id}/tokens (revoke all outstanding tokens). Both
+// require the caller's own session to match {id}; this endpoint issues and
+// revokes tokens for yourself, not on behalf of other users.
+func handleUserTokens(service *UserService, tokens TokenStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/token"):
+			id, ok := userIDFromPath(r.URL.Path, "/token")
+			if !ok {
+				writeJSONError(w, http.StatusBadRequest, "invalid user ID")
+				return
+			}
+			if requestUserID(r) != id {
+				writeJSONError(w, http.StatusForbidden, "cannot issue a token for another user")
+				return
+			}
+
+			ttl, err := parseTTL(r.URL.Query().Get("ttl"))
+			if err != nil {
+				writeJSONError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			signed, err := service.IssueToken(tokens, id, ttl)
+			if err != nil {
+				writeServiceError(w, err)
+				return
+			}
+
+			writeJSON(w, http.StatusCreated, struct {
+				Token string `json:"token"`
+			}{signed})
+
+		case r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/tokens"):
+			id, ok := userIDFromPath(r.URL.Path, "/tokens")
+			if !ok {
+				writeJSONError(w, http.StatusBadRequest, "invalid user ID")
+				return
+			}
+			if requestUserID(r) != id {
+				writeJSONError(w, http.StatusForbidden, "cannot revoke tokens for another user")
+				return
+			}
+
+			if err := service.RevokeTokens(tokens, id); err != nil {
+				writeServiceError(w, err)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			writeJSONError(w, http.StatusNotFound, "not found")
+		}
+	}
+}
+
+// userIDFromPath extracts the {id} segment from a "/users/{id}<suffix>" path.
+func userIDFromPath(path, suffix string) (int, bool) {
+	path = strings.TrimPrefix(path, "/users/")
+	path = strings.TrimSuffix(path, suffix)
+
+	id, err := strconv.Atoi(path)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+// parseTTL parses the ttl query parameter as a Go duration (e.g. "1h"). An
+// empty value means the token never expires; a non-empty value that fails
+// to parse is an error rather than silently falling back to unlimited,
+// since that distinction is security-relevant.
+func parseTTL(raw string) (time.Duration, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ttl %q: %w", raw, err)
+	}
+	return ttl, nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import "time"
+
+// IssuedToken records metadata about a JWT issued to a user, letting
+// DELETE /users/{id}/tokens revoke outstanding tokens instead of just
+// rotating a user's signing secret.
+type IssuedToken struct {
+	JTI       string
+	UserID    int
+	IssuedAt  time.Time
+	RevokedAt time.Time // zero value means not revoked
+}
+
+// TokenStore persists IssuedToken metadata. MemoryStore and SQLStore both
+// implement it alongside Store, over the same backing storage.
+type TokenStore interface {
+	Save(t *IssuedToken) error
+	GetToken(jti string) (*IssuedToken, error)
+	RevokeAll(userID int) error
+}
+
+func (s *MemoryStore) Save(t *IssuedToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cp := *t
+	s.tokens[t.JTI] = &cp
+	return nil
+}
+
+func (s *MemoryStore) GetToken(jti string) (*IssuedToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tokens[jti]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *t
+	return &cp, nil
+}
+
+func (s *MemoryStore) RevokeAll(userID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, t := range s.tokens {
+		if t.UserID == userID && t.RevokedAt.IsZero() {
+			t.RevokedAt = now
+		}
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// User represents a user in the system.
+type User struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	IsActive     bool   `json:"is_active"`
+	PasswordHash string `json:"-"`
+	TokenSecret  string `json:"-"`
+}
+
+// UserService handles user operations against a pluggable Store.
+type UserService struct {
+	store Store
+}
+
+// NewUserService creates a new user service backed by store.
+func NewUserService(store Store) *UserService {
+	return &UserService{store: store}
+}
+
+// CreateUser adds a new user to the service, storing a bcrypt hash of
+// password rather than the password itself.
+func (us *UserService) CreateUser(name, email, password string) (*User, error) {
+	if name == "" || email == "" || password == "" {
+		return nil, fmt.Errorf("name, email and password are required")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("hash password: %w", err)
+	}
+
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, fmt.Errorf("generate token secret: %w", err)
+	}
+
+	user := &User{
+		Name:         name,
+		Email:        email,
+		IsActive:     true,
+		PasswordHash: string(hash),
+		TokenSecret:  secret,
+	}
+	if err := us.store.Create(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Authenticate verifies email/password credentials and returns the matching
+// user on success.
+func (us *UserService) Authenticate(email, password string) (*User, error) {
+	users, err := us.store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		if user.Email != email {
+			continue
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+			return nil, ErrNotFound
+		}
+		if !user.IsActive {
+			return nil, ErrNotFound
+		}
+		return user, nil
+	}
+	return nil, ErrNotFound
+}
+
+// GetUser retrieves a user by ID. Disabled users are still returned; callers
+// can check User.IsActive to see whether the user is disabled.
+func (us *UserService) GetUser(id int) (*User, error) {
+	if id <= 0 {
+		return nil, ErrInvalidID
+	}
+	return us.store.Get(id)
+}
+
+// ListFilter selects which users ListUsers returns.
+type ListFilter string
+
+const (
+	ListAll      ListFilter = "all"
+	ListActive   ListFilter = "active"
+	ListDisabled ListFilter = "disabled"
+)
+
+// ListUsers returns the users matching filter. An empty filter behaves like
+// ListActive, preserving the service's original default.
+func (us *UserService) ListUsers(filter ListFilter) ([]*User, error) {
+	users, err := us.store.List()
+	if err != nil {
+		return nil, err
+	}
+	return applyFilter(users, filter), nil
+}
+
+// Disable sets whether the user identified by id is disabled, without
+// removing the underlying record.
+func (us *UserService) Disable(id int, disabled bool) error {
+	if id <= 0 {
+		return ErrInvalidID
+	}
+	return us.store.Disable(id, disabled)
+}
+
+// GetMultipleByID returns the users identified by ids, keyed by ID. IDs that
+// don't resolve to a user are simply omitted from the result. It backs the
+// bulk lookup endpoint GET /users?ids=1,2,3.
+func (us *UserService) GetMultipleByID(ids ...int) map[int]*User {
+	result := make(map[int]*User, len(ids))
+	for _, id := range ids {
+		user, err := us.store.Get(id)
+		if err != nil {
+			continue
+		}
+		result[id] = user
+	}
+	return result
+}
+
+// ListOptions configures a single page of a paginated user listing.
+type ListOptions struct {
+	Limit int    // max users to return; 0 means no limit
+	After string // opaque cursor from a previous UserPage.Next
+	Sort  string // "created" (default) or "name"
+}
+
+// UserPage is a single page of a paginated user listing.
+type UserPage struct {
+	Users []*User
+	Next  string // opaque cursor for the next page, empty when there is none
+}
+
+// ListPage returns a single page of users matching filter, ordered and
+// paginated according to opts. Cursors encode an opaque (sort_key, id) tuple
+// so the wire format is unaffected by which Store implementation is behind
+// the service.
+func (us *UserService) ListPage(filter ListFilter, opts ListOptions) (*UserPage, error) {
+	users, err := us.store.List()
+	if err != nil {
+		return nil, err
+	}
+	users = applyFilter(users, filter)
+
+	key := sortKeyFunc(opts.Sort)
+	sort.Slice(users, func(i, j int) bool {
+		if ki, kj := key(users[i]), key(users[j]); ki != kj {
+			return ki < kj
+		}
+		return users[i].ID < users[j].ID
+	})
+
+	if opts.After != "" {
+		afterKey, afterID, err := decodeCursor(opts.After)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor: %w", err)
+		}
+		start := 0
+		for start < len(users) {
+			k := key(users[start])
+			if k > afterKey || (k == afterKey && users[start].ID > afterID) {
+				break
+			}
+			start++
+		}
+		users = users[start:]
+	}
+
+	limit := opts.Limit
+	if limit <= 0 || limit > len(users) {
+		limit = len(users)
+	}
+
+	page := &UserPage{Users: users[:limit]}
+	if limit < len(users) {
+		last := page.Users[len(page.Users)-1]
+		page.Next = encodeCursor(key(last), last.ID)
+	}
+	return page, nil
+}
+
+// sortKeyFunc returns the sort-key extractor for the given Sort option.
+func sortKeyFunc(sortBy string) func(*User) string {
+	if sortBy == "name" {
+		return func(u *User) string { return u.Name }
+	}
+	return func(u *User) string { return fmt.Sprintf("%010d", u.ID) }
+}
+
+// applyFilter returns the subset of users matching filter. An empty filter
+// behaves like ListActive, preserving the service's original default.
+func applyFilter(users []*User, filter ListFilter) []*User {
+	if filter == "" {
+		filter = ListActive
+	}
+
+	var filtered []*User
+	for _, user := range users {
+		switch filter {
+		case ListAll:
+			filtered = append(filtered, user)
+		case ListDisabled:
+			if !user.IsActive {
+				filtered = append(filtered, user)
+			}
+		default: // ListActive
+			if user.IsActive {
+				filtered = append(filtered, user)
+			}
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func newTestUsers(t *testing.T, n int) (*UserService, []*User) {
+	t.Helper()
+
+	service := NewUserService(NewMemoryStore())
+	users := make([]*User, n)
+	for i := 0; i < n; i++ {
+		user, err := service.CreateUser(
+			fmt.Sprintf("User %d", i),
+			fmt.Sprintf("user%d@example.com", i),
+			"password",
+		)
+		if err != nil {
+			t.Fatalf("CreateUser: %v", err)
+		}
+		users[i] = user
+	}
+	return service, users
+}
+
+func TestListPagePaginatesAcrossBoundary(t *testing.T) {
+	service, users := newTestUsers(t, 5)
+
+	page, err := service.ListPage(ListActive, ListOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("ListPage: %v", err)
+	}
+	if len(page.Users) != 2 || page.Users[0].ID != users[0].ID || page.Users[1].ID != users[1].ID {
+		t.Fatalf("first page = %v, want users[0:2]", page.Users)
+	}
+	if page.Next == "" {
+		t.Fatal("first page.Next is empty, want a cursor since more users remain")
+	}
+
+	page, err = service.ListPage(ListActive, ListOptions{Limit: 2, After: page.Next})
+	if err != nil {
+		t.Fatalf("ListPage after first page: %v", err)
+	}
+	if len(page.Users) != 2 || page.Users[0].ID != users[2].ID || page.Users[1].ID != users[3].ID {
+		t.Fatalf("second page = %v, want users[2:4]", page.Users)
+	}
+	if page.Next == "" {
+		t.Fatal("second page.Next is empty, want a cursor since one user remains")
+	}
+
+	page, err = service.ListPage(ListActive, ListOptions{Limit: 2, After: page.Next})
+	if err != nil {
+		t.Fatalf("ListPage after second page: %v", err)
+	}
+	if len(page.Users) != 1 || page.Users[0].ID != users[4].ID {
+		t.Fatalf("third page = %v, want users[4:5]", page.Users)
+	}
+	if page.Next != "" {
+		t.Fatalf("third page.Next = %q, want empty since no users remain", page.Next)
+	}
+}
+
+func TestListPageLimitClampedToAvailable(t *testing.T) {
+	service, users := newTestUsers(t, 3)
+
+	page, err := service.ListPage(ListActive, ListOptions{Limit: 100})
+	if err != nil {
+		t.Fatalf("ListPage: %v", err)
+	}
+	if len(page.Users) != len(users) {
+		t.Fatalf("len(page.Users) = %d, want %d", len(page.Users), len(users))
+	}
+	if page.Next != "" {
+		t.Fatalf("page.Next = %q, want empty since the limit covers every user", page.Next)
+	}
+}
+
+func TestGetMultipleByID(t *testing.T) {
+	service, users := newTestUsers(t, 3)
+
+	missingID := users[2].ID + 1000
+	result := service.GetMultipleByID(users[0].ID, missingID, users[1].ID)
+
+	if len(result) != 2 {
+		t.Fatalf("len(result) = %d, want 2", len(result))
+	}
+	if result[users[0].ID] == nil || result[users[0].ID].ID != users[0].ID {
+		t.Fatalf("result[%d] = %v, want user %d", users[0].ID, result[users[0].ID], users[0].ID)
+	}
+	if result[users[1].ID] == nil || result[users[1].ID].ID != users[1].ID {
+		t.Fatalf("result[%d] = %v, want user %d", users[1].ID, result[users[1].ID], users[1].ID)
+	}
+	if _, ok := result[missingID]; ok {
+		t.Fatalf("result[%d] present, want missing ID omitted", missingID)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// validate performs a minimal required-field check driven by `validate`
+// struct tags. It only understands "required" on string fields, which is
+// all the request types in this package need.
+func validate(v interface{}) error {
+	val := reflect.ValueOf(v)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	typ := val.Type()
+
+	var missing []string
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !strings.Contains(field.Tag.Get("validate"), "required") {
+			continue
+		}
+		if val.Field(i).Kind() == reflect.String && val.Field(i).String() == "" {
+			missing = append(missing, field.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required field(s): %s", strings.Join(missing, ", "))
+	}
+	return nil
+}